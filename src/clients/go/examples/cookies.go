@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/justbe-engineering/webview-client-go/webview"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loadContent := webview.ContentFrom(webview.NewUrlContent("https://example.com"))
+
+	profileDir := "./webview-profile"
+	options := webview.Options{
+		Title:             "Cookies Example",
+		Load:              &loadContent,
+		PersistentSession: &profileDir,
+	}
+
+	wv, err := webview.NewWebView(ctx, options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer wv.Close()
+
+	wv.On("started", func(event interface{}) {
+		u, _ := url.Parse("https://example.com")
+
+		cookies, err := wv.Cookies().Get(u)
+		if err != nil {
+			log.Printf("Failed to read cookies: %v", err)
+			return
+		}
+		fmt.Printf("Found %d cookies for %s\n", len(cookies), u)
+
+		// Cookies() is also a net/http.CookieJar, so it can be shared
+		// directly with a Go http.Client for hybrid requests.
+		client := &http.Client{Jar: wv.Cookies()}
+		_ = client
+	})
+
+	wv.On("closed", func(event interface{}) {
+		fmt.Println("WebView closed!")
+	})
+
+	if err := wv.Wait(); err != nil {
+		log.Printf("WebView exited with error: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/justbe-engineering/webview-client-go/webview"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loadContent := webview.ContentFrom(webview.NewUrlContent("app://index.html"))
+
+	options := webview.Options{
+		Title:         "Custom Scheme Example",
+		Load:          &loadContent,
+		CustomSchemes: []string{"app"},
+	}
+
+	wv, err := webview.NewWebView(ctx, options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer wv.Close()
+
+	// Serve ./dist under the app:// scheme, just like a normal Go web server.
+	fileServer := http.FileServer(http.Dir("./dist"))
+	if err := wv.HandleScheme("app", fileServer); err != nil {
+		log.Fatalf("Failed to register app scheme: %v", err)
+	}
+
+	wv.On("closed", func(event interface{}) {
+		fmt.Println("WebView closed!")
+	})
+
+	if err := wv.Wait(); err != nil {
+		log.Printf("WebView exited with error: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/justbe-engineering/webview-client-go/webview"
+)
+
+func main() {
+	ctx := context.Background()
+
+	ds, err := webview.NewDevServer(ctx, webview.DevOptions{
+		Root:       "./dist",
+		Entry:      "index.html",
+		WatchGlobs: []string{"**/*.{html,css,js}"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer ds.Close()
+	defer ds.WebView().Close()
+
+	ds.OnRebuild(func(changed []string) error {
+		fmt.Printf("Reloading after change to: %v\n", changed)
+		return nil
+	})
+
+	ds.WebView().On("closed", func(event interface{}) {
+		fmt.Println("WebView closed!")
+	})
+
+	if err := ds.WebView().Wait(); err != nil {
+		log.Printf("WebView exited with error: %v", err)
+	}
+}
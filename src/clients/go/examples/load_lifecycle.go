@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/justbe-engineering/webview-client-go/webview"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loadContent := webview.ContentFrom(webview.NewUrlContent("https://example.com"))
+
+	options := webview.Options{
+		Title: "Load Lifecycle Example",
+		Load:  &loadContent,
+	}
+
+	wv, err := webview.NewWebView(ctx, options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer wv.Close()
+
+	wv.On("load-started", func(event interface{}) {
+		started := event.(webview.LoadStartedEvent)
+		fmt.Printf("Navigation started: %s\n", started.URL)
+	})
+
+	var retried sync.Map
+	wv.On("load-failed", func(event interface{}) {
+		failed := event.(webview.LoadFailedEvent)
+		log.Printf("Navigation to %s failed (status %d): %s", failed.URL, failed.StatusCode, failed.Error)
+		// Retry once, similar to a RetryAfter helper.
+		if _, seen := retried.LoadOrStore(failed.URL, true); seen {
+			return
+		}
+		if err := wv.LoadURL(failed.URL, nil); err != nil {
+			log.Printf("Retry failed: %v", err)
+		}
+	})
+
+	wv.On("load-finished", func(event interface{}) {
+		finished := event.(webview.LoadFinishedEvent)
+		fmt.Printf("Navigation finished: %s (status %d)\n", finished.URL, finished.StatusCode)
+	})
+
+	if err := wv.Wait(); err != nil {
+		log.Printf("WebView exited with error: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/justbe-engineering/webview-client-go/webview"
+)
+
+func main() {
+	ctx := context.Background()
+
+	html := `
+<button onclick="window.go.greet('World').then(msg => document.title = msg)">Greet</button>
+<script>
+// window.go isn't defined until the RPC bridge's init script is injected
+// (triggered by the first ExposeFunc or CallJS call on the Go side), so
+// poll for it before registering the JS-side function CallJS will invoke.
+(function waitForBridge() {
+	if (window.go && window.go.expose) {
+		window.go.expose('square', function(n) { return n * n; });
+		return;
+	}
+	setTimeout(waitForBridge, 10);
+})();
+</script>
+`
+
+	loadContent := webview.ContentFrom(webview.NewHtmlContent(html))
+
+	options := webview.Options{
+		Title: "RPC Example",
+		Load:  &loadContent,
+		Ipc:   boolPtr(true),
+	}
+
+	wv, err := webview.NewWebView(ctx, options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer wv.Close()
+
+	// Expose a Go function as window.go.greet(name) in JS.
+	if err := wv.ExposeFunc("greet", func(name string) (string, error) {
+		return fmt.Sprintf("Hello, %s!", name), nil
+	}); err != nil {
+		log.Fatalf("Failed to expose function: %v", err)
+	}
+
+	wv.On("started", func(event interface{}) {
+		// Ask a JS-side function to compute something for us.
+		result, err := wv.CallJS(ctx, "square", 7)
+		if err != nil {
+			log.Printf("CallJS failed: %v", err)
+			return
+		}
+		fmt.Printf("square(7) = %s\n", result)
+	})
+
+	wv.On("closed", func(event interface{}) {
+		fmt.Println("WebView closed!")
+	})
+
+	if err := wv.Wait(); err != nil {
+		log.Printf("WebView exited with error: %v", err)
+	}
+}
+
+// Helper functions for pointer types
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/justbe-engineering/webview-client-go/webview"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loadContent := webview.ContentFrom(webview.NewHtmlContent("<h1>Say cheese!</h1>"))
+
+	options := webview.Options{
+		Title: "Snapshot Example",
+		Load:  &loadContent,
+	}
+
+	wv, err := webview.NewWebView(ctx, options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer wv.Close()
+
+	wv.On("started", func(event interface{}) {
+		file, err := os.Create("snapshot.png")
+		if err != nil {
+			log.Printf("Failed to create snapshot file: %v", err)
+			return
+		}
+		defer file.Close()
+
+		if err := wv.SnapshotPNG(file); err != nil {
+			log.Printf("Failed to capture snapshot: %v", err)
+			return
+		}
+
+		log.Println("Wrote snapshot.png")
+		os.Exit(0)
+	})
+
+	if err := wv.Wait(); err != nil {
+		log.Printf("WebView exited with error: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
@@ -33,6 +34,24 @@ type WebView struct {
 	ctx       context.Context
 	cancel    context.CancelFunc
 	done      chan struct{}
+
+	// exposedFuncs holds Go functions registered via ExposeFunc, keyed by
+	// the name JS calls them under.
+	exposedFuncs map[string]*exposedFunc
+	// rpcReplies correlates outstanding CallJS invocations with the
+	// "result"/"error" envelope that resolves them.
+	rpcReplies map[int]chan rpcEnvelope
+	// rpcBridgeOnce guards registering the reload handlers that keep the
+	// RPC bridge's init script present across navigations.
+	rpcBridgeOnce sync.Once
+
+	// schemeHandlers holds http.Handlers registered via HandleScheme, keyed
+	// by scheme name (without "://").
+	schemeHandlers map[string]http.Handler
+
+	// logger receives structured log records parsed from the child
+	// process's stderr. Defaults to a text handler writing to os.Stderr.
+	logger slog.Handler
 }
 
 // NewWebView creates a new webview instance
@@ -66,6 +85,11 @@ func NewWebView(ctx context.Context, options Options) (*WebView, error) {
 
 	childCtx, cancel := context.WithCancel(ctx)
 
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.NewTextHandler(os.Stderr, nil)
+	}
+
 	wv := &WebView{
 		cmd:       cmd,
 		stdin:     stdin,
@@ -76,6 +100,7 @@ func NewWebView(ctx context.Context, options Options) (*WebView, error) {
 		ctx:       childCtx,
 		cancel:    cancel,
 		done:      make(chan struct{}),
+		logger:    logger,
 	}
 
 	if err := cmd.Start(); err != nil {
@@ -117,12 +142,21 @@ func (wv *WebView) processMessages() {
 	}
 }
 
-// processStderr handles stderr from the webview process
+// processStderr handles stderr from the webview process, forwarding
+// structured log lines to wv.logger and falling back to raw passthrough
+// for anything that isn't a JSON log record.
 func (wv *WebView) processStderr() {
 	scanner := bufio.NewScanner(wv.stderr)
 	for scanner.Scan() {
-		// For now, just print stderr to help with debugging
-		fmt.Fprintf(os.Stderr, "webview stderr: %s\n", scanner.Text())
+		line := scanner.Text()
+
+		record, ok := parseStderrLogRecord(line)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "webview stderr: %s\n", line)
+			continue
+		}
+
+		wv.emitLogRecord(record)
 	}
 }
 
@@ -158,12 +192,31 @@ func (wv *WebView) handleNotification(messageMap map[string]interface{}) {
 		return
 	}
 
+	if notificationType == "ipc" {
+		if envelope, ok := parseRPCEnvelope(data["message"]); ok {
+			go wv.dispatchRPCEnvelope(envelope)
+			return
+		}
+	}
+
+	if notificationType == "schemeRequest" {
+		if req, ok := decodeSchemeRequest(data); ok {
+			go wv.dispatchSchemeRequest(req)
+			return
+		}
+	}
+
 	wv.mutex.RLock()
 	handlers := wv.handlers[notificationType]
 	wv.mutex.RUnlock()
 
+	var event interface{} = data
+	if loadLifecycleEvents[notificationType] {
+		event = decodeLoadEvent(notificationType, data)
+	}
+
 	for _, handler := range handlers {
-		go handler(data)
+		go handler(event)
 	}
 }
 
@@ -228,6 +281,24 @@ func (wv *WebView) send(request map[string]interface{}) (Response, error) {
 	}
 }
 
+// sendAsync writes request to the webview process's stdin without waiting
+// for (or expecting) a reply. It's for messages the protocol treats as a
+// one-way push rather than a request/response pair, such as schemeResponse
+// — using send for those would register a responses entry and block until
+// wv.ctx is done, since no matching "response" message ever arrives.
+func (wv *WebView) sendAsync(request map[string]interface{}) error {
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	if _, err := wv.stdin.Write(append(requestJSON, '\n')); err != nil {
+		return fmt.Errorf("failed to write request: %w", err)
+	}
+
+	return nil
+}
+
 // Eval executes JavaScript in the webview
 func (wv *WebView) Eval(js string) (interface{}, error) {
 	request := map[string]interface{}{
@@ -0,0 +1,192 @@
+package webview
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// CookieJar is a net/http.CookieJar-compatible view over the webview's
+// cookie store, so a Go http.Client can be configured to share cookies
+// with the webview for hybrid scraping/automation workflows.
+type CookieJar interface {
+	// SetCookies implements http.CookieJar.
+	SetCookies(u *url.URL, cookies []*http.Cookie)
+	// Cookies implements http.CookieJar.
+	Cookies(u *url.URL) []*http.Cookie
+
+	// Get returns the cookies visible to u. It is equivalent to Cookies
+	// but returns an error instead of an empty slice on failure.
+	Get(u *url.URL) ([]*http.Cookie, error)
+	// Set adds or updates cookie for u.
+	Set(u *url.URL, cookie *http.Cookie) error
+	// Delete removes the cookie named name for u.
+	Delete(u *url.URL, name string) error
+	// Clear removes every cookie in the store.
+	Clear() error
+}
+
+// cookieJar is the WebView-backed CookieJar implementation returned by
+// WebView.Cookies.
+type cookieJar struct {
+	wv *WebView
+}
+
+// Cookies returns a CookieJar backed by this webview's cookie store. If
+// Options.PersistentSession names a profile directory, the store survives
+// across restarts.
+func (wv *WebView) Cookies() CookieJar {
+	return &cookieJar{wv: wv}
+}
+
+// SetCookies implements http.CookieJar by setting each cookie in turn,
+// logging nothing and returning nothing on failure per the interface's
+// contract; use Set for error-aware callers.
+func (j *cookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	for _, cookie := range cookies {
+		_ = j.Set(u, cookie)
+	}
+}
+
+// Cookies implements http.CookieJar.
+func (j *cookieJar) Cookies(u *url.URL) []*http.Cookie {
+	cookies, err := j.Get(u)
+	if err != nil {
+		return nil
+	}
+	return cookies
+}
+
+// Get returns the cookies visible to u.
+func (j *cookieJar) Get(u *url.URL) ([]*http.Cookie, error) {
+	request := map[string]interface{}{
+		"$type": "getCookies",
+		"url":   u.String(),
+	}
+
+	response, err := j.wv.send(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid response format")
+	}
+
+	responseType, ok := responseMap["$type"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing response type")
+	}
+
+	switch responseType {
+	case "result":
+		result, ok := responseMap["result"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid result format")
+		}
+		return decodeCookies(result["value"])
+	case "err":
+		message, _ := responseMap["message"].(string)
+		return nil, fmt.Errorf("webview error: %s", message)
+	default:
+		return nil, fmt.Errorf("unexpected response type: %s", responseType)
+	}
+}
+
+// Set adds or updates cookie for u.
+func (j *cookieJar) Set(u *url.URL, cookie *http.Cookie) error {
+	request := map[string]interface{}{
+		"$type":  "setCookie",
+		"url":    u.String(),
+		"cookie": cookie.String(),
+	}
+
+	response, err := j.wv.send(request)
+	if err != nil {
+		return err
+	}
+
+	return ackOrError(response)
+}
+
+// Delete removes the cookie named name for u. Per RFC 6265, an expiring
+// Set-Cookie only overwrites an existing cookie if its Path and Domain
+// match exactly, so the existing cookie is looked up first and its
+// attributes are carried over onto the deletion cookie.
+func (j *cookieJar) Delete(u *url.URL, name string) error {
+	deletion := &http.Cookie{Name: name, Value: "", MaxAge: -1}
+
+	cookies, err := j.Get(u)
+	if err != nil {
+		return err
+	}
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			deletion.Path = cookie.Path
+			deletion.Domain = cookie.Domain
+			break
+		}
+	}
+
+	return j.Set(u, deletion)
+}
+
+// Clear removes every cookie in the store.
+func (j *cookieJar) Clear() error {
+	request := map[string]interface{}{
+		"$type": "clearCookies",
+	}
+
+	response, err := j.wv.send(request)
+	if err != nil {
+		return err
+	}
+
+	return ackOrError(response)
+}
+
+// ackOrError interprets the common "ack"/"err" response shape shared by
+// the mutating cookie requests.
+func ackOrError(response Response) error {
+	responseMap, ok := response.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid response format")
+	}
+
+	responseType, ok := responseMap["$type"].(string)
+	if !ok {
+		return fmt.Errorf("missing response type")
+	}
+
+	switch responseType {
+	case "ack":
+		return nil
+	case "err":
+		message, _ := responseMap["message"].(string)
+		return fmt.Errorf("webview error: %s", message)
+	default:
+		return fmt.Errorf("unexpected response type: %s", responseType)
+	}
+}
+
+// decodeCookies parses the raw Set-Cookie-formatted strings returned by
+// the webview process into http.Cookie values.
+func decodeCookies(value interface{}) ([]*http.Cookie, error) {
+	rawCookies, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid cookies payload")
+	}
+
+	header := http.Header{}
+	for _, raw := range rawCookies {
+		line, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		header.Add("Set-Cookie", line)
+	}
+
+	response := http.Response{Header: header}
+	return response.Cookies(), nil
+}
@@ -0,0 +1,324 @@
+package webview
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// devReloadDebounce is how long the watcher waits after the last observed
+// filesystem event before triggering a reload, coalescing editor save
+// bursts (e.g. atomic rename-based saves that touch several paths at once).
+const devReloadDebounce = 100 * time.Millisecond
+
+// styleExtensions are treated as safe to hot-swap in place rather than
+// triggering a full page reload.
+var styleExtensions = map[string]bool{".css": true}
+
+// DevOptions configures a DevServer.
+type DevOptions struct {
+	// Root is the directory served to the webview and watched for changes.
+	Root string
+	// Entry is the file loaded on startup, relative to Root.
+	Entry string
+	// WatchGlobs restricts which files under Root trigger a rebuild,
+	// matched against the file's path relative to Root using forward
+	// slashes. Supports "*" (any run of characters within a path
+	// segment), "**" (any run of characters across segments, so
+	// "**/*.css" matches "app.css" and "nested/dir/app.css" alike), "?",
+	// and a single "{a,b,c}" alternation group. An empty slice watches
+	// everything under Root.
+	WatchGlobs []string
+	// Scheme is the custom scheme Root is served under. Defaults to "app".
+	Scheme string
+}
+
+// DevServer serves Root to a webview and reloads it whenever a watched file
+// changes, for use during local development.
+type DevServer struct {
+	wv      *WebView
+	watcher *fsnotify.Watcher
+	options DevOptions
+	globs   []*regexp.Regexp
+
+	mutex     sync.Mutex
+	onRebuild func(changed []string) error
+
+	debounce *time.Timer
+	pending  map[string]struct{}
+}
+
+// NewDevServer starts a webview serving options.Root under a custom scheme,
+// loads options.Entry, and installs an fsnotify watcher on options.Root
+// (and every subdirectory beneath it, since fsnotify does not watch
+// recursively) that reloads the webview on change. The caller is
+// responsible for closing the returned DevServer's WebView via
+// DevServer.WebView().Close().
+func NewDevServer(ctx context.Context, options DevOptions) (*DevServer, error) {
+	if options.Scheme == "" {
+		options.Scheme = "app"
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("webview: failed to create filesystem watcher: %w", err)
+	}
+
+	if err := addWatcherRecursive(watcher, options.Root); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("webview: failed to watch %q: %w", options.Root, err)
+	}
+
+	entryURL := fmt.Sprintf("%s://%s", options.Scheme, strings.TrimPrefix(options.Entry, "/"))
+	loadContent := ContentFrom(NewUrlContent(entryURL))
+	wv, err := NewWebView(ctx, Options{
+		Title:         "Dev Server",
+		Load:          &loadContent,
+		CustomSchemes: []string{options.Scheme},
+	})
+	if err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("webview: failed to start dev server webview: %w", err)
+	}
+
+	if err := wv.HandleScheme(options.Scheme, http.FileServer(http.Dir(options.Root))); err != nil {
+		_ = watcher.Close()
+		_ = wv.Close()
+		return nil, fmt.Errorf("webview: failed to register dev server scheme: %w", err)
+	}
+
+	ds := &DevServer{
+		wv:      wv,
+		watcher: watcher,
+		options: options,
+		globs:   compileGlobs(options.WatchGlobs),
+		pending: make(map[string]struct{}),
+	}
+
+	go ds.watch(ctx)
+
+	return ds, nil
+}
+
+// addWatcherRecursive adds root and every directory beneath it to watcher,
+// since fsnotify only watches the directories it's explicitly given, not
+// their descendants.
+func addWatcherRecursive(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// WebView returns the underlying WebView so callers can register handlers,
+// evaluate JS, or close it when the dev server is no longer needed.
+func (ds *DevServer) WebView() *WebView {
+	return ds.wv
+}
+
+// OnRebuild registers a hook invoked with the list of changed paths right
+// before a reload is triggered. If fn returns an error, the reload is
+// skipped and the error is logged to stderr; this lets callers run their
+// own build step (e.g. bundling) before the webview picks up new files.
+func (ds *DevServer) OnRebuild(fn func(changed []string) error) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.onRebuild = fn
+}
+
+// Close stops the filesystem watcher. It does not close the underlying
+// WebView; call ds.WebView().Close() for that.
+func (ds *DevServer) Close() error {
+	return ds.watcher.Close()
+}
+
+// watch consumes fsnotify events until ctx is done or the watcher is
+// closed, debouncing bursts of changes before triggering a reload. Newly
+// created subdirectories are added to the watcher on the fly, since
+// fsnotify's watch set is fixed at Add time.
+func (ds *DevServer) watch(ctx context.Context) {
+	for {
+		select {
+		case event, ok := <-ds.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = addWatcherRecursive(ds.watcher, event.Name)
+				}
+			}
+			if !ds.matchesWatchGlobs(event.Name) {
+				continue
+			}
+			ds.scheduleReload(event.Name)
+		case <-ds.watcher.Errors:
+			// Watcher errors (e.g. a transient stat failure) aren't fatal;
+			// the watcher keeps running.
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// matchesWatchGlobs reports whether path should trigger a reload, per
+// options.WatchGlobs matched against path relative to Root. An empty
+// WatchGlobs matches everything.
+func (ds *DevServer) matchesWatchGlobs(path string) bool {
+	if len(ds.globs) == 0 {
+		return true
+	}
+
+	rel, err := filepath.Rel(ds.options.Root, path)
+	if err != nil {
+		rel = path
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, glob := range ds.globs {
+		if glob.MatchString(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileGlobs expands each pattern's brace alternation (if any) and
+// compiles the result to a regexp, so WatchGlobs can use the same
+// "**/*.{html,css,js}"-style syntax common to JS build tooling.
+func compileGlobs(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		for _, variant := range expandBraces(pattern) {
+			compiled = append(compiled, compileGlob(variant))
+		}
+	}
+	return compiled
+}
+
+// expandBraces expands a single "{a,b,c}" alternation group in pattern
+// into one pattern per option. Patterns without a brace group are
+// returned unchanged. Nested groups are not supported.
+func expandBraces(pattern string) []string {
+	start := strings.Index(pattern, "{")
+	end := strings.Index(pattern, "}")
+	if start == -1 || end == -1 || end < start {
+		return []string{pattern}
+	}
+
+	prefix, suffix := pattern[:start], pattern[end+1:]
+	options := strings.Split(pattern[start+1:end], ",")
+
+	variants := make([]string, 0, len(options))
+	for _, option := range options {
+		variants = append(variants, prefix+option+suffix)
+	}
+	return variants
+}
+
+// compileGlob translates a glob pattern into an anchored regexp. "**"
+// matches any run of characters, including "/"; "*" matches any run of
+// characters within a single path segment; "?" matches a single
+// non-separator character.
+func compileGlob(pattern string) *regexp.Regexp {
+	var out strings.Builder
+	out.WriteString("^")
+
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			out.WriteString("(?:.*/)?")
+			i += 3
+		case strings.HasPrefix(pattern[i:], "**"):
+			out.WriteString(".*")
+			i += 2
+		case pattern[i] == '*':
+			out.WriteString("[^/]*")
+			i++
+		case pattern[i] == '?':
+			out.WriteString("[^/]")
+			i++
+		default:
+			out.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			i++
+		}
+	}
+
+	out.WriteString("$")
+	return regexp.MustCompile(out.String())
+}
+
+// scheduleReload records path as pending and (re)arms the debounce timer,
+// so a burst of saves collapses into a single reload.
+func (ds *DevServer) scheduleReload(path string) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	ds.pending[path] = struct{}{}
+
+	if ds.debounce != nil {
+		ds.debounce.Stop()
+	}
+	ds.debounce = time.AfterFunc(devReloadDebounce, ds.flushReload)
+}
+
+// flushReload runs the OnRebuild hook (if any) and reloads the webview,
+// choosing a CSS hot-swap when every pending change is a stylesheet and a
+// full page reload otherwise.
+func (ds *DevServer) flushReload() {
+	ds.mutex.Lock()
+	changed := make([]string, 0, len(ds.pending))
+	styleOnly := true
+	for path := range ds.pending {
+		changed = append(changed, path)
+		if !styleExtensions[filepath.Ext(path)] {
+			styleOnly = false
+		}
+	}
+	ds.pending = make(map[string]struct{})
+	onRebuild := ds.onRebuild
+	ds.mutex.Unlock()
+
+	if len(changed) == 0 {
+		return
+	}
+
+	if onRebuild != nil {
+		if err := onRebuild(changed); err != nil {
+			fmt.Fprintf(os.Stderr, "webview: dev server rebuild failed: %v\n", err)
+			return
+		}
+	}
+
+	if styleOnly {
+		_, _ = ds.wv.Eval(hotSwapStylesheetsScript)
+		return
+	}
+
+	_, _ = ds.wv.Eval("location.reload()")
+}
+
+// hotSwapStylesheetsScript busts the cache on every <link rel="stylesheet">
+// tag by re-appending its href with a fresh query string, avoiding a full
+// page reload for style-only changes.
+const hotSwapStylesheetsScript = `
+document.querySelectorAll('link[rel="stylesheet"]').forEach(function(link) {
+	var url = new URL(link.href);
+	url.searchParams.set('t', Date.now());
+	link.href = url.toString();
+});
+`
@@ -0,0 +1,164 @@
+package webview
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDevServerSchemeRoundTripsNestedAsset is a smoke check that the
+// http.FileServer DevServer wires up via HandleScheme actually serves a
+// nested asset's own content, not the directory root index that a
+// mis-normalized scheme URL would fall back to.
+func TestDevServerSchemeRoundTripsNestedAsset(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "assets"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "index.html"), []byte("<html>index</html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "assets", "app.css"), []byte("body { color: red; }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := http.FileServer(http.Dir(root))
+
+	httpReq, err := buildSchemeHTTPRequest(schemeRequest{Method: http.MethodGet, URL: "app://assets/app.css"}, nil)
+	if err != nil {
+		t.Fatalf("buildSchemeHTTPRequest: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httpReq)
+
+	body, err := io.ReadAll(recorder.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(body), "body { color: red; }"; got != want {
+		t.Fatalf("nested asset did not round-trip: got %q, want %q", got, want)
+	}
+}
+
+// TestMatchesWatchGlobs exercises compileGlob's "**" and "{a,b,c}" support
+// the way NewDevServer wires it up from DevOptions.WatchGlobs.
+func TestMatchesWatchGlobs(t *testing.T) {
+	ds := &DevServer{
+		options: DevOptions{Root: "/project", WatchGlobs: []string{"**/*.{html,css,js}"}},
+	}
+	ds.globs = compileGlobs(ds.options.WatchGlobs)
+
+	cases := []struct {
+		path  string
+		match bool
+	}{
+		{"/project/index.html", true},
+		{"/project/src/app.js", true},
+		{"/project/styles/nested/app.css", true},
+		{"/project/data.json", false},
+		{"/project/.gitignore", false},
+	}
+
+	for _, tc := range cases {
+		if got := ds.matchesWatchGlobs(tc.path); got != tc.match {
+			t.Errorf("matchesWatchGlobs(%q) = %v, want %v", tc.path, got, tc.match)
+		}
+	}
+}
+
+// newTestWebView returns a WebView wired to an in-memory stand-in for the
+// webview process: every outgoing eval request is echoed back as a
+// successful result, and the js it carried is pushed onto the returned
+// channel, so tests can drive code that calls wv.Eval without a real
+// subprocess.
+func newTestWebView(t *testing.T) (*WebView, <-chan string) {
+	t.Helper()
+
+	pr, pw := io.Pipe()
+	evaluated := make(chan string, 8)
+	wv := &WebView{
+		stdin:     pw,
+		ctx:       context.Background(),
+		responses: make(map[int]chan Response),
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			var req map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+				continue
+			}
+			if js, ok := req["js"].(string); ok {
+				evaluated <- js
+			}
+			id, _ := req["id"].(float64)
+			wv.handleMessage(map[string]interface{}{
+				"$type": "response",
+				"data": map[string]interface{}{
+					"id":     id,
+					"$type":  "result",
+					"result": map[string]interface{}{"value": nil},
+				},
+			})
+		}
+	}()
+	t.Cleanup(func() { _ = pw.Close() })
+
+	return wv, evaluated
+}
+
+// TestFlushReloadStyleOnly checks that a batch of pending changes made up
+// entirely of stylesheets triggers the CSS hot-swap script rather than a
+// full page reload.
+func TestFlushReloadStyleOnly(t *testing.T) {
+	wv, evaluated := newTestWebView(t)
+	ds := &DevServer{
+		wv:      wv,
+		options: DevOptions{Root: "."},
+		pending: map[string]struct{}{"styles/app.css": {}},
+	}
+
+	ds.flushReload()
+
+	select {
+	case js := <-evaluated:
+		if !strings.Contains(js, "querySelectorAll") {
+			t.Fatalf("expected stylesheet hot-swap script, got %q", js)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for flushReload to eval")
+	}
+}
+
+// TestFlushReloadFullReloadOnNonStyleChange checks that a batch containing
+// even one non-stylesheet change falls back to a full page reload.
+func TestFlushReloadFullReloadOnNonStyleChange(t *testing.T) {
+	wv, evaluated := newTestWebView(t)
+	ds := &DevServer{
+		wv:      wv,
+		options: DevOptions{Root: "."},
+		pending: map[string]struct{}{"index.html": {}, "styles/app.css": {}},
+	}
+
+	ds.flushReload()
+
+	select {
+	case js := <-evaluated:
+		if js != "location.reload()" {
+			t.Fatalf("expected full reload, got %q", js)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for flushReload to eval")
+	}
+}
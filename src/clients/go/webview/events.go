@@ -0,0 +1,79 @@
+package webview
+
+import "encoding/json"
+
+// decodeInto round-trips a loosely-typed notification data map through
+// JSON into a typed event struct, the same approach used for other
+// untyped IPC payloads in this package.
+func decodeInto(data map[string]interface{}, target interface{}) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(raw, target)
+}
+
+// LoadStartedEvent is dispatched when the webview begins navigating to a
+// new URL.
+type LoadStartedEvent struct {
+	URL string `json:"url"`
+}
+
+// LoadCommittedEvent is dispatched once the navigation has been accepted
+// and content has started arriving, mirroring WebKit's load-changed
+// signal at LOAD_COMMITTED.
+type LoadCommittedEvent struct {
+	URL string `json:"url"`
+}
+
+// LoadFinishedEvent is dispatched when a navigation completes
+// successfully.
+type LoadFinishedEvent struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"statusCode"`
+}
+
+// LoadFailedEvent is dispatched when a navigation fails, e.g. due to a
+// network error or a non-2xx response the caller wants to treat as a
+// failure.
+type LoadFailedEvent struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+}
+
+// loadLifecycleEvents is the set of notification $type strings emitted by
+// the webview process that decodeLoadEvent turns into typed event structs
+// before dispatching to handlers registered via On.
+var loadLifecycleEvents = map[string]bool{
+	"load-started":   true,
+	"load-committed": true,
+	"load-finished":  true,
+	"load-failed":    true,
+}
+
+// decodeLoadEvent unmarshals a load lifecycle notification's data into its
+// typed event struct so handlers registered via On receive a concrete type
+// instead of a raw map.
+func decodeLoadEvent(notificationType string, data map[string]interface{}) interface{} {
+	switch notificationType {
+	case "load-started":
+		var event LoadStartedEvent
+		decodeInto(data, &event)
+		return event
+	case "load-committed":
+		var event LoadCommittedEvent
+		decodeInto(data, &event)
+		return event
+	case "load-finished":
+		var event LoadFinishedEvent
+		decodeInto(data, &event)
+		return event
+	case "load-failed":
+		var event LoadFailedEvent
+		decodeInto(data, &event)
+		return event
+	default:
+		return data
+	}
+}
@@ -0,0 +1,59 @@
+package webview
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+// stderrLogRecord is the JSON shape the webview process emits on stderr
+// for structured log lines. Lines that don't parse as this shape are
+// written to os.Stderr as-is behind a "webview stderr: " prefix, so
+// plain-text panics and crash traces are never swallowed.
+type stderrLogRecord struct {
+	Level   string            `json:"level"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields"`
+}
+
+// parseStderrLogRecord attempts to decode line as a structured log record.
+func parseStderrLogRecord(line string) (stderrLogRecord, bool) {
+	var record stderrLogRecord
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		return stderrLogRecord{}, false
+	}
+	if record.Message == "" {
+		return stderrLogRecord{}, false
+	}
+	return record, true
+}
+
+// emitLogRecord forwards a parsed stderr log record to wv.logger.
+func (wv *WebView) emitLogRecord(record stderrLogRecord) {
+	if wv.logger == nil {
+		return
+	}
+
+	slogRecord := slog.NewRecord(time.Now(), slogLevel(record.Level), record.Message, 0)
+	for key, value := range record.Fields {
+		slogRecord.AddAttrs(slog.String(key, value))
+	}
+
+	_ = wv.logger.Handle(context.Background(), slogRecord)
+}
+
+// slogLevel maps the webview process's level strings onto slog's levels,
+// defaulting to Info for anything unrecognized.
+func slogLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
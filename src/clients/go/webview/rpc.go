@@ -0,0 +1,360 @@
+package webview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// rpcBridgeReloadEvents are the load lifecycle events after which the RPC
+// bridge's init script must be re-evaluated, since a navigation replaces
+// the page's JS state (including window.go) but doesn't restart the
+// webview process.
+var rpcBridgeReloadEvents = [...]string{"load-committed"}
+
+// rpcEnvelope is the JSON shape exchanged over window.ipc.postMessage for the
+// RPC bridge. Kind is one of "call", "result", or "error".
+type rpcEnvelope struct {
+	Kind  string          `json:"kind"`
+	ID    int             `json:"id"`
+	Name  string          `json:"name"`
+	Args  json.RawMessage `json:"args,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// rpcInitScript wraps window.ipc.postMessage so JS-side calls to
+// window.go.<name>(...) are proxied to Go via ExposeFunc, and JS-side
+// functions registered with window.go.expose(name, fn) can be invoked from
+// Go via CallJS.
+const rpcInitScript = `
+(function() {
+	if (window.go) return;
+	var pending = {};
+	var exposed = {};
+	var nextId = 1;
+
+	window.go = {
+		expose: function(name, fn) { exposed[name] = fn; },
+	};
+
+	function send(envelope) {
+		window.ipc.postMessage(JSON.stringify(envelope));
+	}
+
+	window.__webviewRpcDispatch = function(raw) {
+		var envelope = JSON.parse(raw);
+		if (envelope.kind === 'call') {
+			var fn = exposed[envelope.name];
+			if (!fn) {
+				send({kind: 'error', id: envelope.id, name: envelope.name, error: 'no JS function exposed: ' + envelope.name});
+				return;
+			}
+			Promise.resolve()
+				.then(function() { return fn.apply(null, envelope.args || []); })
+				.then(function(value) { send({kind: 'result', id: envelope.id, name: envelope.name, value: value}); })
+				.catch(function(err) { send({kind: 'error', id: envelope.id, name: envelope.name, error: String(err && err.message || err)}); });
+			return;
+		}
+		var waiter = pending[envelope.id];
+		if (!waiter) return;
+		delete pending[envelope.id];
+		if (envelope.kind === 'error') {
+			waiter.reject(new Error(envelope.error));
+		} else {
+			waiter.resolve(envelope.value);
+		}
+	};
+
+	window.go.call = function(name) {
+		var args = Array.prototype.slice.call(arguments, 1);
+		var id = nextId++;
+		return new Promise(function(resolve, reject) {
+			pending[id] = {resolve: resolve, reject: reject};
+			send({kind: 'call', id: id, name: name, args: args});
+		});
+	};
+})();
+`
+
+// rpcExposeStubScript defines window.go.<name> as a thin wrapper around
+// window.go.call(name, ...args), so ExposeFunc's registered name is directly
+// callable rather than only reachable through the generic call() form.
+const rpcExposeStubScript = `
+(function(name) {
+	window.go[name] = function() {
+		return window.go.call.apply(null, [name].concat(Array.prototype.slice.call(arguments)));
+	};
+})(%s);
+`
+
+// exposedFunc holds a reflected Go function registered with ExposeFunc.
+type exposedFunc struct {
+	name  string
+	value reflect.Value
+}
+
+// ExposeFunc registers fn under name so JS code can call it as
+// window.go.<name>(...args), which returns a Promise resolving to fn's
+// return value (or rejecting on error or panic). fn's arguments are
+// unmarshaled from the JS-supplied JSON array; fn may optionally return
+// (T, error) or just T.
+//
+// The RPC bridge's init script is injected into the current page
+// immediately, and re-injected after every subsequent navigation (since
+// LoadURL/LoadHTML replace the page's JS state), so ExposeFunc only needs
+// to be called once per WebView regardless of how many times it navigates.
+func (wv *WebView) ExposeFunc(name string, fn interface{}) error {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func {
+		return fmt.Errorf("webview: ExposeFunc %q: fn must be a function, got %s", name, fnValue.Kind())
+	}
+
+	wv.mutex.Lock()
+	if wv.exposedFuncs == nil {
+		wv.exposedFuncs = make(map[string]*exposedFunc)
+	}
+	wv.exposedFuncs[name] = &exposedFunc{name: name, value: fnValue}
+	wv.mutex.Unlock()
+
+	wv.installRPCBridge()
+
+	if _, err := wv.Eval(rpcInitScript); err != nil {
+		return fmt.Errorf("webview: failed to install RPC bridge: %w", err)
+	}
+
+	if err := wv.injectExposeStub(name); err != nil {
+		return fmt.Errorf("webview: failed to install stub for %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// injectExposeStub evaluates rpcExposeStubScript for name, defining
+// window.go.<name> on the current page.
+func (wv *WebView) injectExposeStub(name string) error {
+	_, err := wv.Eval(fmt.Sprintf(rpcExposeStubScript, mustMarshalJSON(name)))
+	return err
+}
+
+// installRPCBridge registers the reload handlers that keep the RPC bridge
+// present across navigations. It only registers them once per WebView, no
+// matter how many times ExposeFunc or CallJS-related setup runs.
+func (wv *WebView) installRPCBridge() {
+	wv.rpcBridgeOnce.Do(func() {
+		for _, eventType := range rpcBridgeReloadEvents {
+			wv.On(eventType, func(event interface{}) {
+				if _, err := wv.Eval(rpcInitScript); err != nil {
+					// The webview may already be closing; there's no one
+					// left to report this to.
+					return
+				}
+
+				wv.mutex.RLock()
+				names := make([]string, 0, len(wv.exposedFuncs))
+				for name := range wv.exposedFuncs {
+					names = append(names, name)
+				}
+				wv.mutex.RUnlock()
+
+				for _, name := range names {
+					_ = wv.injectExposeStub(name)
+				}
+			})
+		}
+	})
+}
+
+// CallJS invokes the JS function previously registered with
+// window.go.expose(name, fn) and unmarshals its resolved value into a
+// json.RawMessage. It returns ctx.Err() if ctx is done before the JS side
+// responds.
+//
+// CallJS installs the RPC bridge itself, the same as ExposeFunc, so it
+// works independently of whether ExposeFunc has ever been called on this
+// WebView.
+func (wv *WebView) CallJS(ctx context.Context, name string, args ...interface{}) (json.RawMessage, error) {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("webview: failed to marshal CallJS args: %w", err)
+	}
+
+	wv.installRPCBridge()
+
+	if _, err := wv.Eval(rpcInitScript); err != nil {
+		return nil, fmt.Errorf("webview: failed to install RPC bridge: %w", err)
+	}
+
+	wv.mutex.Lock()
+	id := wv.messageID
+	wv.messageID++
+	replyChan := make(chan rpcEnvelope, 1)
+	if wv.rpcReplies == nil {
+		wv.rpcReplies = make(map[int]chan rpcEnvelope)
+	}
+	wv.rpcReplies[id] = replyChan
+	wv.mutex.Unlock()
+
+	js := fmt.Sprintf("window.__webviewRpcDispatch(%s)", mustMarshalJSON(rpcEnvelope{
+		Kind: "call",
+		ID:   id,
+		Name: name,
+		Args: argsJSON,
+	}))
+	if _, err := wv.Eval(js); err != nil {
+		wv.mutex.Lock()
+		delete(wv.rpcReplies, id)
+		wv.mutex.Unlock()
+		return nil, fmt.Errorf("webview: CallJS %q: %w", name, err)
+	}
+
+	select {
+	case envelope := <-replyChan:
+		if envelope.Kind == "error" {
+			return nil, fmt.Errorf("webview: JS function %q rejected: %s", name, envelope.Error)
+		}
+		return envelope.Value, nil
+	case <-ctx.Done():
+		wv.mutex.Lock()
+		delete(wv.rpcReplies, id)
+		wv.mutex.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// dispatchRPCEnvelope handles an inbound "call" envelope by invoking the
+// matching exposed Go function and posting back a "result" or "error"
+// envelope, or routes an inbound "result"/"error" envelope to a pending
+// CallJS caller.
+func (wv *WebView) dispatchRPCEnvelope(envelope rpcEnvelope) {
+	if envelope.Kind != "call" {
+		wv.mutex.RLock()
+		replyChan, ok := wv.rpcReplies[envelope.ID]
+		wv.mutex.RUnlock()
+		if ok {
+			replyChan <- envelope
+			wv.mutex.Lock()
+			delete(wv.rpcReplies, envelope.ID)
+			wv.mutex.Unlock()
+		}
+		return
+	}
+
+	wv.mutex.RLock()
+	fn, ok := wv.exposedFuncs[envelope.Name]
+	wv.mutex.RUnlock()
+	if !ok {
+		wv.replyRPCError(envelope, fmt.Errorf("no Go function exposed: %s", envelope.Name))
+		return
+	}
+
+	value, err := callExposedFunc(fn, envelope.Args)
+	if err != nil {
+		wv.replyRPCError(envelope, err)
+		return
+	}
+
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		wv.replyRPCError(envelope, fmt.Errorf("failed to marshal result: %w", err))
+		return
+	}
+
+	reply := rpcEnvelope{Kind: "result", ID: envelope.ID, Name: envelope.Name, Value: valueJSON}
+	_, _ = wv.Eval(fmt.Sprintf("window.__webviewRpcDispatch(%s)", mustMarshalJSON(reply)))
+}
+
+// replyRPCError posts a "error" envelope back to JS in response to a failed
+// or panicking exposed function call.
+func (wv *WebView) replyRPCError(envelope rpcEnvelope, err error) {
+	reply := rpcEnvelope{Kind: "error", ID: envelope.ID, Name: envelope.Name, Error: err.Error()}
+	_, _ = wv.Eval(fmt.Sprintf("window.__webviewRpcDispatch(%s)", mustMarshalJSON(reply)))
+}
+
+// callExposedFunc unmarshals argsJSON into fn's parameter types via
+// reflection, invokes fn, and recovers any panic as an error so it can be
+// propagated as a JS rejection instead of crashing the process.
+func callExposedFunc(fn *exposedFunc, argsJSON json.RawMessage) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in exposed function %q: %v", fn.name, r)
+		}
+	}()
+
+	var rawArgs []json.RawMessage
+	if len(argsJSON) > 0 {
+		if unmarshalErr := json.Unmarshal(argsJSON, &rawArgs); unmarshalErr != nil {
+			return nil, fmt.Errorf("failed to unmarshal args for %q: %w", fn.name, unmarshalErr)
+		}
+	}
+
+	fnType := fn.value.Type()
+	callArgs := make([]reflect.Value, fnType.NumIn())
+	for i := range callArgs {
+		argType := fnType.In(i)
+		argPtr := reflect.New(argType)
+		if i < len(rawArgs) {
+			if unmarshalErr := json.Unmarshal(rawArgs[i], argPtr.Interface()); unmarshalErr != nil {
+				return nil, fmt.Errorf("failed to unmarshal arg %d for %q: %w", i, fn.name, unmarshalErr)
+			}
+		}
+		callArgs[i] = argPtr.Elem()
+	}
+
+	results := fn.value.Call(callArgs)
+	switch len(results) {
+	case 0:
+		return nil, nil
+	case 1:
+		if errValue, ok := results[0].Interface().(error); ok {
+			return nil, errValue
+		}
+		return results[0].Interface(), nil
+	default:
+		last := results[len(results)-1].Interface()
+		if errValue, ok := last.(error); ok && errValue != nil {
+			return nil, errValue
+		}
+		if len(results) == 2 {
+			return results[0].Interface(), nil
+		}
+		values := make([]interface{}, len(results)-1)
+		for i, v := range results[:len(results)-1] {
+			values[i] = v.Interface()
+		}
+		return values, nil
+	}
+}
+
+// parseRPCEnvelope attempts to parse an "ipc" notification's message field
+// as an RPC envelope. Plain, non-RPC IPC messages (e.g. from
+// window.ipc.postMessage(string)) fail to unmarshal here and are left for
+// ordinary "ipc" event handlers registered via On.
+func parseRPCEnvelope(message interface{}) (rpcEnvelope, bool) {
+	raw, ok := message.(string)
+	if !ok {
+		return rpcEnvelope{}, false
+	}
+
+	var envelope rpcEnvelope
+	if err := json.Unmarshal([]byte(raw), &envelope); err != nil {
+		return rpcEnvelope{}, false
+	}
+	if envelope.Kind != "call" && envelope.Kind != "result" && envelope.Kind != "error" {
+		return rpcEnvelope{}, false
+	}
+
+	return envelope, true
+}
+
+// mustMarshalJSON marshals v to a JSON string, panicking on failure. It is
+// only used for internal envelope types whose fields are always
+// JSON-serializable.
+func mustMarshalJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("webview: failed to marshal internal envelope: %v", err))
+	}
+	return string(data)
+}
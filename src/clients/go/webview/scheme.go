@@ -0,0 +1,210 @@
+package webview
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+)
+
+// schemeRequest is the notification payload sent by the webview process
+// when it intercepts a request for a registered custom scheme. Body is
+// base64-encoded on the wire, matching the encoding snapshot.go already
+// uses for binary payloads, since it may carry arbitrary binary data
+// (e.g. a POST body from a fetch() call).
+type schemeRequest struct {
+	ID      int               `json:"id"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// HandleScheme registers handler to serve requests made to URLs using
+// scheme (e.g. "app" for "app://index.html"). The webview process forwards
+// intercepted requests over stdout; handler is invoked with a synthetic
+// http.ResponseWriter and the response is sent back through stdin. scheme
+// must also be listed in Options.CustomSchemes when the webview is created.
+func (wv *WebView) HandleScheme(scheme string, handler http.Handler) error {
+	if handler == nil {
+		return fmt.Errorf("webview: HandleScheme %q: handler must not be nil", scheme)
+	}
+
+	wv.mutex.Lock()
+	if wv.schemeHandlers == nil {
+		wv.schemeHandlers = make(map[string]http.Handler)
+	}
+	wv.schemeHandlers[scheme] = handler
+	wv.mutex.Unlock()
+
+	return nil
+}
+
+// decodeSchemeRequest converts the loosely-typed notification data map into
+// a schemeRequest by round-tripping through JSON, the same approach used
+// elsewhere for decoding untyped IPC payloads.
+func decodeSchemeRequest(data map[string]interface{}) (schemeRequest, bool) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return schemeRequest{}, false
+	}
+
+	var req schemeRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return schemeRequest{}, false
+	}
+
+	return req, true
+}
+
+// dispatchSchemeRequest invokes the handler registered for the request's
+// scheme and sends the serialized response back through stdin. If no
+// handler is registered a 404 is returned, and if the request's URL or body
+// can't be parsed a 400 is returned, so the webview process doesn't hang
+// waiting for a reply.
+func (wv *WebView) dispatchSchemeRequest(req schemeRequest) {
+	scheme, ok := schemeOf(req.URL)
+	if !ok {
+		wv.replySchemeError(req.ID, http.StatusBadRequest, fmt.Sprintf("invalid URL: %s", req.URL))
+		return
+	}
+
+	wv.mutex.RLock()
+	handler, ok := wv.schemeHandlers[scheme]
+	wv.mutex.RUnlock()
+	if !ok {
+		wv.replySchemeError(req.ID, http.StatusNotFound, fmt.Sprintf("no handler registered for scheme %q", scheme))
+		return
+	}
+
+	body, err := base64.StdEncoding.DecodeString(req.Body)
+	if err != nil {
+		wv.replySchemeError(req.ID, http.StatusBadRequest, fmt.Sprintf("failed to decode request body: %v", err))
+		return
+	}
+
+	httpReq, err := buildSchemeHTTPRequest(req, body)
+	if err != nil {
+		wv.replySchemeError(req.ID, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recorder := newSchemeResponseRecorder()
+	handler.ServeHTTP(recorder, httpReq)
+
+	request := map[string]interface{}{
+		"$type":     "schemeResponse",
+		"requestId": req.ID,
+		"status":    recorder.status,
+		"headers":   flattenHeader(recorder.Header()),
+		"body":      base64.StdEncoding.EncodeToString(recorder.body.Bytes()),
+	}
+	if err := wv.sendAsync(request); err != nil {
+		// The webview process may have already moved on (e.g. navigation
+		// was cancelled); there's no one left to report this to.
+		_ = err
+	}
+}
+
+// buildSchemeHTTPRequest converts a schemeRequest into an *http.Request
+// suitable for handler.ServeHTTP. For a custom-scheme URL like
+// "app://index.html" or "app://assets/logo.png", url.Parse puts the first
+// path segment in Host and leaves the rest in Path (there being no real
+// host to distinguish). Fold Host back into Path so handlers like
+// http.FileServer see the whole thing as a path instead of resolving every
+// request to "/".
+func buildSchemeHTTPRequest(req schemeRequest, body []byte) (*http.Request, error) {
+	httpReq, err := http.NewRequest(req.Method, req.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.URL.Path = "/" + httpReq.URL.Host + httpReq.URL.Path
+	httpReq.URL.Host = ""
+	httpReq.Host = ""
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	return httpReq, nil
+}
+
+// replySchemeError sends a minimal error response for a scheme request that
+// couldn't be routed to a handler.
+func (wv *WebView) replySchemeError(requestID int, status int, message string) {
+	request := map[string]interface{}{
+		"$type":     "schemeResponse",
+		"requestId": requestID,
+		"status":    status,
+		"headers":   map[string]string{"Content-Type": "text/plain"},
+		"body":      base64.StdEncoding.EncodeToString([]byte(message)),
+	}
+	_ = wv.sendAsync(request)
+}
+
+// schemeResponseRecorder is a minimal http.ResponseWriter that buffers the
+// handler's output so it can be serialized back to the webview process.
+type schemeResponseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newSchemeResponseRecorder() *schemeResponseRecorder {
+	return &schemeResponseRecorder{header: make(http.Header), status: http.StatusOK}
+}
+
+func (r *schemeResponseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *schemeResponseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *schemeResponseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+var _ io.Writer = (*schemeResponseRecorder)(nil)
+
+// flattenHeader converts an http.Header into a single-valued map suitable
+// for JSON transport, joining repeated headers with ", " per RFC 7230.
+func flattenHeader(header http.Header) map[string]string {
+	flat := make(map[string]string, len(header))
+	for key, values := range header {
+		flat[textproto.CanonicalMIMEHeaderKey(key)] = joinHeaderValues(values)
+	}
+	return flat
+}
+
+func joinHeaderValues(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	joined := ""
+	for i, v := range values {
+		if i > 0 {
+			joined += ", "
+		}
+		joined += v
+	}
+	return joined
+}
+
+// schemeOf extracts the scheme component of a URL string (the part before
+// "://"), without pulling in a full net/url parse.
+func schemeOf(rawURL string) (string, bool) {
+	for i := 0; i < len(rawURL); i++ {
+		if rawURL[i] == ':' {
+			if i+2 < len(rawURL) && rawURL[i+1] == '/' && rawURL[i+2] == '/' {
+				return rawURL[:i], true
+			}
+			return "", false
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,212 @@
+package webview
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// SnapshotFormat selects the pixel encoding used when rasterizing a
+// webview snapshot.
+type SnapshotFormat string
+
+const (
+	SnapshotFormatPNG  SnapshotFormat = "png"
+	SnapshotFormatJPEG SnapshotFormat = "jpeg"
+	SnapshotFormatRGBA SnapshotFormat = "rgba"
+)
+
+// SnapshotOptions configures a Snapshot capture.
+type SnapshotOptions struct {
+	// Region restricts the capture to a sub-rectangle of the page. The
+	// zero value captures the full viewport.
+	Region image.Rectangle
+	// Scale is the device scale factor applied to the capture, e.g. 2 for
+	// a retina-resolution image. Zero defaults to 1.
+	Scale float64
+	// Format selects the wire encoding used for the capture. Defaults to
+	// SnapshotFormatPNG.
+	Format SnapshotFormat
+	// FullPage scrolls and stitches the page so captures taller than the
+	// viewport are captured in full, rather than being clipped to it.
+	FullPage bool
+}
+
+// Snapshot requests a rasterized image of the current webview contents and
+// decodes it into an image.Image. Region and FullPage are mutually
+// exclusive; if both are set, Region wins.
+func (wv *WebView) Snapshot(opts SnapshotOptions) (image.Image, error) {
+	format, value, err := wv.requestSnapshot(opts)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnapshot(format, value)
+}
+
+// requestSnapshot sends the snapshot request and returns the resolved
+// format (defaulted from opts.Format) alongside the still-undecoded wire
+// payload, so callers can either decode it into an image.Image (Snapshot)
+// or, for formats that are already an encoded image on the wire, use the
+// bytes directly (SnapshotPNG).
+func (wv *WebView) requestSnapshot(opts SnapshotOptions) (SnapshotFormat, interface{}, error) {
+	format := opts.Format
+	if format == "" {
+		format = SnapshotFormatPNG
+	}
+
+	request := map[string]interface{}{
+		"$type":    "snapshot",
+		"format":   string(format),
+		"fullPage": opts.FullPage && opts.Region.Empty(),
+	}
+	if opts.Scale != 0 {
+		request["scale"] = opts.Scale
+	}
+	if !opts.Region.Empty() {
+		request["region"] = map[string]int{
+			"x":      opts.Region.Min.X,
+			"y":      opts.Region.Min.Y,
+			"width":  opts.Region.Dx(),
+			"height": opts.Region.Dy(),
+		}
+	}
+
+	response, err := wv.send(request)
+	if err != nil {
+		return format, nil, err
+	}
+
+	responseMap, ok := response.(map[string]interface{})
+	if !ok {
+		return format, nil, fmt.Errorf("invalid response format")
+	}
+
+	responseType, ok := responseMap["$type"].(string)
+	if !ok {
+		return format, nil, fmt.Errorf("missing response type")
+	}
+
+	switch responseType {
+	case "result":
+		result, ok := responseMap["result"].(map[string]interface{})
+		if !ok {
+			return format, nil, fmt.Errorf("invalid result format")
+		}
+		return format, result["value"], nil
+	case "err":
+		message, _ := responseMap["message"].(string)
+		return format, nil, fmt.Errorf("webview error: %s", message)
+	default:
+		return format, nil, fmt.Errorf("unexpected response type: %s", responseType)
+	}
+}
+
+// SnapshotPNG captures the current webview contents and writes the
+// already-PNG-encoded wire payload to w directly, without decoding it into
+// an image.Image and re-encoding it.
+func (wv *WebView) SnapshotPNG(w io.Writer) error {
+	_, value, err := wv.requestSnapshot(SnapshotOptions{Format: SnapshotFormatPNG})
+	if err != nil {
+		return err
+	}
+	raw, err := decodeSnapshotBytes(value)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(raw)
+	return err
+}
+
+// decodeSnapshot decodes the wire payload for value into an image.Image.
+// For the png/jpeg formats, value is the base64-encoded, already-encoded
+// image. For rgba, value is an object carrying the raw pixel bytes
+// alongside the width/height needed to reconstruct an image.RGBA, since
+// raw pixels alone don't carry their own dimensions.
+func decodeSnapshot(format SnapshotFormat, value interface{}) (image.Image, error) {
+	switch format {
+	case SnapshotFormatPNG:
+		raw, err := decodeSnapshotBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		img, err := png.Decode(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode PNG snapshot: %w", err)
+		}
+		return img, nil
+	case SnapshotFormatJPEG:
+		raw, err := decodeSnapshotBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		img, err := jpeg.Decode(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode JPEG snapshot: %w", err)
+		}
+		return img, nil
+	case SnapshotFormatRGBA:
+		return decodeSnapshotRGBA(value)
+	default:
+		return nil, fmt.Errorf("unsupported snapshot format: %s", format)
+	}
+}
+
+// decodeSnapshotBytes base64-decodes a plain string wire payload, as used
+// by the png/jpeg formats.
+func decodeSnapshotBytes(value interface{}) ([]byte, error) {
+	data, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid snapshot payload")
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot payload: %w", err)
+	}
+	return raw, nil
+}
+
+// decodeSnapshotRGBA decodes the rgba wire format: an object carrying
+// width, height, and base64-encoded raw RGBA pixel bytes, into an
+// image.RGBA.
+func decodeSnapshotRGBA(value interface{}) (image.Image, error) {
+	payload, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid rgba snapshot payload")
+	}
+
+	width, ok := payload["width"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("rgba snapshot missing width")
+	}
+	height, ok := payload["height"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("rgba snapshot missing height")
+	}
+	data, ok := payload["data"].(string)
+	if !ok {
+		return nil, fmt.Errorf("rgba snapshot missing data")
+	}
+
+	pix, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode rgba snapshot payload: %w", err)
+	}
+
+	w, h := int(width), int(height)
+	if w < 0 || h < 0 {
+		return nil, fmt.Errorf("rgba snapshot has invalid dimensions %dx%d", w, h)
+	}
+	if len(pix) != w*h*4 {
+		return nil, fmt.Errorf("rgba snapshot data length %d does not match %dx%d", len(pix), w, h)
+	}
+
+	return &image.RGBA{
+		Pix:    pix,
+		Stride: w * 4,
+		Rect:   image.Rect(0, 0, w, h),
+	}, nil
+}